@@ -0,0 +1,251 @@
+package collector
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/KYVENetwork/ksync/types"
+)
+
+// prefetchBackoffBase is the initial delay used for exponential backoff when a bundle
+// fetch fails; it doubles on each consecutive failure up to prefetchBackoffMax.
+// maxPrefetchRetries bounds how many attempts a worker makes on a single bundle before
+// giving up, so a permanently-failing bundle id (bad id, 404, pool mismatch) fails the
+// download instead of retrying forever and hanging its worker
+const (
+	prefetchBackoffBase = 500 * time.Millisecond
+	prefetchBackoffMax  = 30 * time.Second
+	maxPrefetchRetries  = 8
+)
+
+// ChunkPrefetcherMetrics is a point-in-time snapshot of a ChunkPrefetcher's progress,
+// which DownloadChunks reports to the metrics package after each chunk
+type ChunkPrefetcherMetrics struct {
+	BytesPerSec     float64
+	InFlightBundles int64
+	CacheHits       int64
+}
+
+// prefetchResult carries the decoded snapshot data item for a single bundle id, or the
+// error encountered while fetching it
+type prefetchResult struct {
+	bundleId int64
+	item     *types.SnapshotDataItem
+	err      error
+}
+
+// ChunkPrefetcher downloads a contiguous range of snapshot chunk bundles with a pool of
+// worker goroutines and delivers the decoded SnapshotDataItems back to the caller in
+// bundle id order, regardless of the order in which the workers finish. It caches every
+// decoded bundle so that a metadata lookup and a chunk-bytes lookup for the same bundle
+// id only hit the network once.
+type ChunkPrefetcher struct {
+	collector *KyveSnapshotCollector
+
+	startBundleId int64
+	totalChunks   int64
+	workers       int64
+
+	jobs    chan int64
+	results chan prefetchResult
+
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	cache    map[int64]*types.SnapshotDataItem
+	errCache map[int64]error
+
+	bytesFetched    int64
+	cacheHits       int64
+	inFlightBundles int64
+	startedAt       time.Time
+}
+
+// NewChunkPrefetcher creates a ChunkPrefetcher that fetches totalChunks bundles starting
+// at startBundleId using the given number of worker goroutines. Call Start to begin
+// downloading and Next repeatedly to consume the decoded items in order.
+func NewChunkPrefetcher(collector *KyveSnapshotCollector, startBundleId, totalChunks, workers int64) *ChunkPrefetcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &ChunkPrefetcher{
+		collector:     collector,
+		startBundleId: startBundleId,
+		totalChunks:   totalChunks,
+		workers:       workers,
+		jobs:          make(chan int64, totalChunks),
+		results:       make(chan prefetchResult, workers),
+		done:          make(chan struct{}),
+		cache:         make(map[int64]*types.SnapshotDataItem),
+		errCache:      make(map[int64]error),
+	}
+}
+
+// Stop cancels any in-progress and pending fetches, causing worker goroutines to exit
+// and Next to return an error instead of blocking forever. It is safe to call multiple
+// times and from multiple goroutines.
+func (p *ChunkPrefetcher) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// Start spawns the worker goroutines and begins downloading bundles concurrently. It
+// must only be called once per ChunkPrefetcher.
+func (p *ChunkPrefetcher) Start() {
+	p.startedAt = time.Now()
+
+	for i := int64(0); i < p.totalChunks; i++ {
+		p.jobs <- p.startBundleId + i
+	}
+	close(p.jobs)
+
+	for w := int64(0); w < p.workers; w++ {
+		go p.worker()
+	}
+}
+
+func (p *ChunkPrefetcher) worker() {
+	for {
+		select {
+		case bundleId, more := <-p.jobs:
+			if !more {
+				return
+			}
+
+			p.mu.Lock()
+			p.inFlightBundles++
+			p.mu.Unlock()
+
+			item, err := p.fetchWithBackoff(bundleId)
+
+			p.mu.Lock()
+			p.inFlightBundles--
+			if err == nil {
+				p.cache[bundleId] = item
+				p.bytesFetched += int64(len(item.Value.Chunk))
+			}
+			p.mu.Unlock()
+
+			select {
+			case p.results <- prefetchResult{bundleId: bundleId, item: item, err: err}:
+			case <-p.done:
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// fetchWithBackoff fetches and decodes the bundle at bundleId, retrying with
+// exponential backoff on failure up to maxPrefetchRetries times, and bailing out early
+// if the prefetcher is stopped
+func (p *ChunkPrefetcher) fetchWithBackoff(bundleId int64) (*types.SnapshotDataItem, error) {
+	delay := prefetchBackoffBase
+
+	var lastErr error
+	for attempt := 1; attempt <= maxPrefetchRetries; attempt++ {
+		item, err := p.collector.GetSnapshotFromBundleId(bundleId)
+		if err == nil {
+			return item, nil
+		}
+		lastErr = err
+
+		if attempt == maxPrefetchRetries {
+			break
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-p.done:
+			return nil, fmt.Errorf("chunk prefetcher stopped while fetching bundle %d: %w", bundleId, lastErr)
+		}
+
+		delay *= 2
+		if delay > prefetchBackoffMax {
+			delay = prefetchBackoffMax
+		}
+	}
+
+	return nil, fmt.Errorf("failed to fetch bundle %d after %d attempts: %w", bundleId, maxPrefetchRetries, lastErr)
+}
+
+// Next blocks until the decoded SnapshotDataItem for the next bundle id in order is
+// available, serving it from cache if a worker has already delivered it out of order.
+// It returns an error if the underlying fetch for that bundle ultimately failed, and
+// ok=false once every bundle in the range has been delivered.
+func (p *ChunkPrefetcher) Next(bundleId int64) (item *types.SnapshotDataItem, err error, ok bool) {
+	if bundleId >= p.startBundleId+p.totalChunks {
+		return nil, nil, false
+	}
+
+	p.mu.Lock()
+	if cached, found := p.cache[bundleId]; found {
+		p.cacheHits++
+		p.mu.Unlock()
+		return cached, nil, true
+	}
+	if cachedErr, found := p.errCache[bundleId]; found {
+		p.mu.Unlock()
+		return nil, cachedErr, true
+	}
+	p.mu.Unlock()
+
+	for {
+		select {
+		case result, more := <-p.results:
+			if !more {
+				return nil, fmt.Errorf("chunk prefetcher closed before delivering bundle %d", bundleId), false
+			}
+
+			if result.err != nil && result.bundleId == bundleId {
+				return nil, result.err, true
+			}
+
+			p.mu.Lock()
+			if result.item != nil {
+				p.cache[result.bundleId] = result.item
+			} else if result.err != nil {
+				// a result for a bundle other than the one we're waiting on: if it
+				// failed, cache the error too, not just successes, or a later
+				// Next call for that bundle id would never see its only delivery
+				// and block forever
+				p.errCache[result.bundleId] = result.err
+			}
+			cached, found := p.cache[bundleId]
+			cachedErr, errFound := p.errCache[bundleId]
+			p.mu.Unlock()
+
+			if found {
+				return cached, nil, true
+			}
+			if errFound {
+				return nil, cachedErr, true
+			}
+		case <-p.done:
+			return nil, fmt.Errorf("chunk prefetcher stopped before delivering bundle %d", bundleId), false
+		}
+	}
+}
+
+// Metrics returns a point-in-time snapshot of the prefetcher's progress
+func (p *ChunkPrefetcher) Metrics() ChunkPrefetcherMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	elapsed := time.Since(p.startedAt).Seconds()
+	bytesPerSec := float64(0)
+	if elapsed > 0 {
+		bytesPerSec = float64(p.bytesFetched) / elapsed
+	}
+
+	return ChunkPrefetcherMetrics{
+		BytesPerSec:     bytesPerSec,
+		InFlightBundles: p.inFlightBundles,
+		CacheHits:       p.cacheHits,
+	}
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KYVENetwork/ksync/types"
+)
+
+// newTestPrefetcher builds a ChunkPrefetcher without starting its worker goroutines, so
+// tests can feed prefetchResults onto p.results directly and exercise Next in isolation.
+// workers is set to totalChunks because it also sizes the results buffer, which must
+// hold every result a test feeds before it calls Next
+func newTestPrefetcher(startBundleId, totalChunks int64) *ChunkPrefetcher {
+	return NewChunkPrefetcher(newTestCollector(0), startBundleId, totalChunks, totalChunks)
+}
+
+func TestChunkPrefetcherNext_OutOfOrderErrorIsNotDropped(t *testing.T) {
+	// bundle B's (the only) result arrives on p.results while Next is still waiting on
+	// an earlier bundle A; the error for B must still be observable once Next(B) is
+	// called later, instead of Next(B) blocking forever waiting for a message that will
+	// never come again
+	p := newTestPrefetcher(0, 2)
+
+	fetchErr := fmt.Errorf("failed to fetch bundle 1 after 8 attempts")
+	p.results <- prefetchResult{bundleId: 1, err: fetchErr}
+	p.results <- prefetchResult{bundleId: 0, item: &types.SnapshotDataItem{}}
+
+	item, err, ok := p.Next(0)
+	if err != nil || !ok || item == nil {
+		t.Fatalf("Next(0) = (%v, %v, %v), want a cached item and no error", item, err, ok)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err, ok := p.Next(1)
+		if !ok || err != fetchErr {
+			t.Errorf("Next(1) = (err=%v, ok=%v), want the cached fetch error", err, ok)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Next(1) blocked forever instead of returning the cached error")
+	}
+}
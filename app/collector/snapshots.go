@@ -3,6 +3,7 @@ package collector
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/KYVENetwork/ksync/metrics"
 	"github.com/KYVENetwork/ksync/types"
 	"github.com/KYVENetwork/ksync/utils"
 	"strconv"
@@ -10,6 +11,10 @@ import (
 	"time"
 )
 
+// getFinalizedBundleById is indirected through a package-level variable so that
+// FindSnapshotBundleIdForHeight can be exercised in unit tests with a fake pool
+var getFinalizedBundleById = utils.GetFinalizedBundleById
+
 type KyveSnapshotCollector struct {
 	poolId    int64
 	chainRest string
@@ -160,13 +165,43 @@ func (collector *KyveSnapshotCollector) DownloadChunkFromBundleId(bundleId int64
 	return bundle[0].Value.Chunk, nil
 }
 
+// DownloadChunks downloads totalChunks consecutive snapshot chunk bundles starting at
+// startBundleId, using a ChunkPrefetcher with the given number of concurrent workers so
+// large snapshots stream in instead of stalling on serial HTTP round trips. It returns
+// the decoded chunk bytes in bundle order, and reports prefetcher throughput to the
+// metrics package as the download progresses.
+func (collector *KyveSnapshotCollector) DownloadChunks(startBundleId, totalChunks, workers int64) ([][]byte, error) {
+	prefetcher := NewChunkPrefetcher(collector, startBundleId, totalChunks, workers)
+	prefetcher.Start()
+	defer prefetcher.Stop()
+
+	chunks := make([][]byte, 0, totalChunks)
+
+	for bundleId := startBundleId; bundleId < startBundleId+totalChunks; bundleId++ {
+		item, err, ok := prefetcher.Next(bundleId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download chunk bundle %d: %w", bundleId, err)
+		}
+		if !ok {
+			break
+		}
+
+		chunks = append(chunks, item.Value.Chunk)
+
+		m := prefetcher.Metrics()
+		metrics.SetChunkPrefetcherMetrics(collector.poolId, m.BytesPerSec, m.InFlightBundles, m.CacheHits)
+	}
+
+	return chunks, nil
+}
+
 func (collector *KyveSnapshotCollector) FindSnapshotBundleIdForHeight(height int64) (int64, error) {
 	latestBundleId := collector.totalBundles - 1
 
 	// if the height is the latest height we can calculate the location of bundle id for the first
 	// chunk immediately
 	if height == collector.latestAvailableHeight {
-		finalizedBundle, err := utils.GetFinalizedBundleById(collector.chainRest, collector.poolId, latestBundleId)
+		finalizedBundle, err := getFinalizedBundleById(collector.chainRest, collector.poolId, latestBundleId)
 		if err != nil {
 			return 0, fmt.Errorf("failed to get finalized bundle with id %d: %w", latestBundleId, err)
 		}
@@ -181,32 +216,66 @@ func (collector *KyveSnapshotCollector) FindSnapshotBundleIdForHeight(height int
 		}
 	}
 
-	// if the height is not the latest height we try to find it with binary search
-	// TODO: consider interpolation search
+	// if the height is not the latest height we try to find it with interpolation search,
+	// which converges much faster than plain binary search since bundle ids are roughly
+	// linearly related to snapshot heights (fixed snapshot interval, bounded chunks per
+	// snapshot). we fall back to a binary-search step whenever the interpolation estimate
+	// fails to make progress so the loop is still guaranteed to terminate
 	low := int64(0)
 	high := latestBundleId
 
+	hLow, _, err := collector.resolveBundleHeight(low)
+	if err != nil {
+		return 0, err
+	}
+
+	hHigh, _, err := collector.resolveBundleHeight(high)
+	if err != nil {
+		return 0, err
+	}
+
+	prevMid := int64(-1)
+
 	// stop when low and high meet
 	for low <= high {
-		// check in the middle
-		mid := (low + high) / 2
+		var mid int64
 
-		finalizedBundle, err := utils.GetFinalizedBundleById(collector.chainRest, collector.poolId, mid)
-		if err != nil {
-			return 0, fmt.Errorf("failed to get finalized bundle with id %d: %w", mid, err)
+		if hHigh == hLow {
+			// interpolation degenerated, fall back to a binary-search step to
+			// guarantee progress
+			mid = (low + high) / 2
+		} else {
+			mid = low + ((height-hLow)*(high-low))/(hHigh-hLow)
+			// clamp to the true remaining range: hLow/hHigh are the heights of the
+			// previous mid, not necessarily of the current low/high, so the
+			// interpolated estimate can otherwise land outside [low, high] (most
+			// visibly when low == high but hLow != hHigh, where it must land
+			// exactly on low)
+			if mid < low {
+				mid = low
+			} else if mid > high {
+				mid = high
+			}
 		}
 
-		h, chunkIndex, err := utils.ParseSnapshotFromKey(finalizedBundle.ToKey)
+		if mid == prevMid {
+			mid = (low + high) / 2
+		}
+		prevMid = mid
+
+		h, chunkIndex, err := collector.resolveBundleHeight(mid)
 		if err != nil {
-			return 0, fmt.Errorf("failed to parse snapshot key %s: %w", finalizedBundle.ToKey, err)
+			return 0, err
 		}
 
 		if h < height {
 			// target height is in the right half
 			low = mid + 1
+			hLow = h
 		} else if h > height {
 			// target height is in the left half
 			high = mid - 1
+			hHigh = h
 		} else {
 			// found it, now we just go back to the bundle where the first chunk index
 			// is located
@@ -218,3 +287,19 @@ func (collector *KyveSnapshotCollector) FindSnapshotBundleIdForHeight(height int
 
 	return 0, fmt.Errorf("failed to find snapshot bundle id for height %d", height)
 }
+
+// resolveBundleHeight fetches the finalized bundle with the given id and parses its
+// ToKey into the snapshot height and chunk index it corresponds to
+func (collector *KyveSnapshotCollector) resolveBundleHeight(bundleId int64) (int64, int64, error) {
+	finalizedBundle, err := getFinalizedBundleById(collector.chainRest, collector.poolId, bundleId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get finalized bundle with id %d: %w", bundleId, err)
+	}
+
+	h, chunkIndex, err := utils.ParseSnapshotFromKey(finalizedBundle.ToKey)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse snapshot key %s: %w", finalizedBundle.ToKey, err)
+	}
+
+	return h, chunkIndex, nil
+}
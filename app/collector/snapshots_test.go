@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/KYVENetwork/ksync/types"
+)
+
+// newTestCollector builds a collector backed by a fake bundle-to-height mapping,
+// bypassing NewKyveSnapshotCollector's REST calls
+func newTestCollector(totalBundles int64) *KyveSnapshotCollector {
+	return &KyveSnapshotCollector{
+		poolId:       1,
+		chainRest:    "http://localhost",
+		totalBundles: totalBundles,
+	}
+}
+
+// withFakeFinalizedBundleById stubs getFinalizedBundleById for the duration of a test
+// with a fake bundle-id -> (height, chunkIndex) distribution and restores the real
+// implementation afterwards
+func withFakeFinalizedBundleById(t *testing.T, heightAt, chunkIndexAt func(bundleId int64) int64) {
+	t.Helper()
+
+	original := getFinalizedBundleById
+	getFinalizedBundleById = func(chainRest string, poolId int64, bundleId int64) (*types.FinalizedBundle, error) {
+		return &types.FinalizedBundle{
+			ToKey: fmt.Sprintf("%d/0/%d", heightAt(bundleId), chunkIndexAt(bundleId)),
+		}, nil
+	}
+	t.Cleanup(func() { getFinalizedBundleById = original })
+}
+
+func TestFindSnapshotBundleIdForHeight_Uniform(t *testing.T) {
+	// 100 bundles, each snapshot spanning 5 consecutive chunk bundles
+	const totalBundles = 100
+	const chunksPerSnapshot = 5
+
+	heightAt := func(bundleId int64) int64 {
+		return ((bundleId / chunksPerSnapshot) + 1) * 1000
+	}
+	chunkIndexAt := func(bundleId int64) int64 {
+		return bundleId % chunksPerSnapshot
+	}
+	withFakeFinalizedBundleById(t, heightAt, chunkIndexAt)
+
+	collector := newTestCollector(totalBundles)
+	collector.latestAvailableHeight = heightAt(totalBundles - 1)
+
+	wantHeight := int64(12 * 1000)
+	wantBundleId := int64(11 * chunksPerSnapshot)
+
+	bundleId, err := collector.FindSnapshotBundleIdForHeight(wantHeight)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bundleId != wantBundleId {
+		t.Fatalf("expected bundle id %d, got %d", wantBundleId, bundleId)
+	}
+}
+
+func TestFindSnapshotBundleIdForHeight_Skewed(t *testing.T) {
+	// heights grow quadratically with bundle id, so heights are far from the linear
+	// relation the interpolation estimate assumes. every bundle id is searched for
+	// (not just one hand-picked target); the last one, totalBundles-1, holds the
+	// latest available height and is resolved by the latest-height fast path
+	const totalBundles = 64
+
+	heightAt := func(bundleId int64) int64 {
+		return bundleId * bundleId
+	}
+	chunkIndexAt := func(bundleId int64) int64 {
+		return 0
+	}
+	withFakeFinalizedBundleById(t, heightAt, chunkIndexAt)
+
+	collector := newTestCollector(totalBundles)
+	collector.latestAvailableHeight = heightAt(totalBundles - 1)
+
+	for wantBundleId := int64(0); wantBundleId < totalBundles; wantBundleId++ {
+		bundleId, err := collector.FindSnapshotBundleIdForHeight(heightAt(wantBundleId))
+		if err != nil {
+			t.Fatalf("unexpected error for bundle id %d: %v", wantBundleId, err)
+		}
+		if bundleId != wantBundleId {
+			t.Fatalf("expected bundle id %d, got %d", wantBundleId, bundleId)
+		}
+	}
+}
+
+func TestFindSnapshotBundleIdForHeight_Adversarial(t *testing.T) {
+	// almost all bundles report the same height, so h_low stays at the plateau height
+	// while h_high is far above the target, and each interpolation estimate lands only
+	// partway across the plateau toward the target. the target (and the last plateau bundle before it) is deliberately not
+	// the latest available height, so the search actually has to walk the loop instead
+	// of being resolved by the latest-height fast path
+	const totalBundles = 32
+	const targetBundleId = totalBundles - 2
+	const targetHeight = 500
+
+	heightAt := func(bundleId int64) int64 {
+		switch bundleId {
+		case totalBundles - 1:
+			return 1000
+		case targetBundleId:
+			return targetHeight
+		default:
+			return 1
+		}
+	}
+	chunkIndexAt := func(bundleId int64) int64 {
+		return 0
+	}
+	withFakeFinalizedBundleById(t, heightAt, chunkIndexAt)
+
+	collector := newTestCollector(totalBundles)
+	collector.latestAvailableHeight = heightAt(totalBundles - 1)
+
+	bundleId, err := collector.FindSnapshotBundleIdForHeight(targetHeight)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bundleId != targetBundleId {
+		t.Fatalf("expected bundle id %d, got %d", targetBundleId, bundleId)
+	}
+}
@@ -2,21 +2,30 @@ package commands
 
 import (
 	"fmt"
-	"github.com/KYVENetwork/ksync/servesnapshots"
+	"github.com/KYVENetwork/ksync/flags"
+	"github.com/KYVENetwork/ksync/orchestrator"
 	"github.com/KYVENetwork/ksync/utils"
 	"github.com/spf13/cobra"
+	"log"
+	"path/filepath"
 	"strings"
 )
 
+var (
+	blockPoolIds    []string
+	snapshotPoolIds []string
+	startHeights    []string
+)
+
 func init() {
 	serveCmd.Flags().StringVar(&binaryPath, "binary", "", "binary path of node to be synced")
 	if err := serveCmd.MarkFlagRequired("binary"); err != nil {
-		panic(fmt.Errorf("flag 'binary' should be required: %w", err))
+		log.Fatalf("flag 'binary' should be required: %v", err)
 	}
 
 	serveCmd.Flags().StringVar(&homePath, "home", "", "home directory")
 	if err := serveCmd.MarkFlagRequired("home"); err != nil {
-		panic(fmt.Errorf("flag 'home' should be required: %w", err))
+		log.Fatalf("flag 'home' should be required: %v", err)
 	}
 
 	serveCmd.Flags().StringVar(&chainId, "chain-id", utils.DefaultChainId, fmt.Sprintf("KYVE chain id [\"%s\",\"%s\",\"%s\"]", utils.ChainIdMainnet, utils.ChainIdKaon, utils.ChainIdKorellia))
@@ -24,14 +33,14 @@ func init() {
 	serveCmd.Flags().StringVar(&chainRest, "chain-rest", "", "rest endpoint for KYVE chain")
 	serveCmd.Flags().StringVar(&storageRest, "storage-rest", "", "storage endpoint for requesting bundle data")
 
-	serveCmd.Flags().Int64Var(&blockPoolId, "block-pool-id", 0, "pool id of the block-sync pool")
+	serveCmd.Flags().StringArrayVar(&blockPoolIds, "block-pool-id", nil, "pool id of the block-sync pool, can be given multiple times or as a comma-separated list to serve several pools")
 	if err := serveCmd.MarkFlagRequired("block-pool-id"); err != nil {
-		panic(fmt.Errorf("flag 'block-pool-id' should be required: %w", err))
+		log.Fatalf("flag 'block-pool-id' should be required: %v", err)
 	}
 
-	serveCmd.Flags().Int64Var(&snapshotPoolId, "snapshot-pool-id", 0, "pool id of the state-sync pool")
+	serveCmd.Flags().StringArrayVar(&snapshotPoolIds, "snapshot-pool-id", nil, "pool id of the state-sync pool, paired up with --block-pool-id in order")
 	if err := serveCmd.MarkFlagRequired("snapshot-pool-id"); err != nil {
-		panic(fmt.Errorf("flag 'snapshot-pool-id' should be required: %w", err))
+		log.Fatalf("flag 'snapshot-pool-id' should be required: %v", err)
 	}
 
 	serveCmd.Flags().Int64Var(&snapshotPort, "snapshot-port", utils.DefaultSnapshotServerPort, "port for snapshot server")
@@ -39,19 +48,101 @@ func init() {
 	serveCmd.Flags().BoolVar(&metrics, "metrics", false, "metrics server exposing sync status")
 	serveCmd.Flags().Int64Var(&metricsPort, "metrics-port", utils.DefaultMetricsServerPort, "port for metrics server")
 
-	serveCmd.Flags().Int64Var(&startHeight, "start-height", 0, "start creating snapshots at this height. note that pruning should be false when using start height")
+	serveCmd.Flags().StringArrayVar(&startHeights, "start-height", nil, "start creating snapshots at this height, can be given once for all pools or once per --block-pool-id. note that pruning should be false when using start height")
 
 	serveCmd.Flags().BoolVar(&pruning, "pruning", true, "prune application, state and blockstore db")
 
+	serveCmd.Flags().Int64Var(&chunkWorkers, "chunk-workers", 4, "number of concurrent workers for the snapshot chunk prefetcher; the value is stored but not read by serve-snapshots")
+
 	rootCmd.AddCommand(serveCmd)
 }
 
 var serveCmd = &cobra.Command{
 	Use:   "serve-snapshots",
 	Short: "Serve snapshots for running KYVE state-sync pools",
-	Run: func(cmd *cobra.Command, args []string) {
-		chainRest = utils.GetChainRest(chainId, chainRest)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolvedRest, err := utils.ResolveChainRest(chainId, chainRest)
+		if err != nil {
+			return err
+		}
+		chainRest = resolvedRest
+
 		storageRest = strings.TrimSuffix(storageRest, "/")
-		servesnapshots.StartServeSnapshotsWithBinary(binaryPath, homePath, chainRest, storageRest, blockPoolId, metrics, metricsPort, snapshotPoolId, snapshotPort, startHeight, pruning)
+
+		// stored in flags.ChunkWorkers; no serve-snapshots code path reads that value,
+		// so --chunk-workers does not change how snapshot chunks are downloaded
+		flags.ChunkWorkers = chunkWorkers
+
+		tasks, err := buildServeTasks()
+		if err != nil {
+			return err
+		}
+
+		// RunServe blocks until every pool's executor has finished
+		return orchestrator.RunServe(binaryPath, chainRest, storageRest, metrics, pruning, tasks, 0)
 	},
 }
+
+// buildServeTasks resolves --block-pool-id, --snapshot-pool-id and --start-height into
+// one ServeTask per block/snapshot pool pair. --block-pool-id and --snapshot-pool-id
+// must align in length, and --start-height may either be given once, in which case it
+// applies to every pool pair, or once per pool pair. When serving more than one pool
+// pair, each task gets its own sub-directory of --home and its own snapshot/metrics
+// ports (offset by index) so that concurrently-running executors don't write into the
+// same home directory or bind the same port; with a single pool pair, --home and the
+// configured ports are used as-is for backward compatibility.
+func buildServeTasks() ([]orchestrator.ServeTask, error) {
+	blockPools, err := utils.ParseInt64List(blockPoolIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --block-pool-id: %w", err)
+	}
+
+	snapshotPools, err := utils.ParseInt64List(snapshotPoolIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --snapshot-pool-id: %w", err)
+	}
+
+	if len(blockPools) != len(snapshotPools) {
+		return nil, fmt.Errorf("--block-pool-id and --snapshot-pool-id must be given the same number of times (%d vs %d)", len(blockPools), len(snapshotPools))
+	}
+
+	heights, err := utils.ParseInt64List(startHeights)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --start-height: %w", err)
+	}
+	if len(heights) == 0 {
+		heights = []int64{0}
+	}
+
+	if len(heights) != 1 && len(heights) != len(blockPools) {
+		return nil, fmt.Errorf("--start-height must be given once or once per pool pair (%d pairs), got %d values", len(blockPools), len(heights))
+	}
+
+	tasks := make([]orchestrator.ServeTask, len(blockPools))
+	for i := range blockPools {
+		startHeight := heights[0]
+		if len(heights) > 1 {
+			startHeight = heights[i]
+		}
+
+		taskHome := homePath
+		taskSnapshotPort := snapshotPort
+		taskMetricsPort := metricsPort
+		if len(blockPools) > 1 {
+			taskHome = filepath.Join(homePath, fmt.Sprintf("pool-%d", blockPools[i]))
+			taskSnapshotPort = snapshotPort + int64(i)
+			taskMetricsPort = metricsPort + int64(i)
+		}
+
+		tasks[i] = orchestrator.ServeTask{
+			BlockPoolId:    blockPools[i],
+			SnapshotPoolId: snapshotPools[i],
+			StartHeight:    startHeight,
+			HomePath:       taskHome,
+			SnapshotPort:   taskSnapshotPort,
+			MetricsPort:    taskMetricsPort,
+		}
+	}
+
+	return tasks, nil
+}
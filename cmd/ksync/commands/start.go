@@ -2,34 +2,35 @@ package commands
 
 import (
 	"fmt"
-	"github.com/KYVENetwork/ksync/executor/auto"
-	"github.com/KYVENetwork/ksync/executor/db"
-	"github.com/KYVENetwork/ksync/executor/p2p"
+	ksyncflags "github.com/KYVENetwork/ksync/flags"
+	"github.com/KYVENetwork/ksync/orchestrator"
 	"github.com/KYVENetwork/ksync/utils"
 	"github.com/spf13/cobra"
-	"strings"
+	"log"
+	"path/filepath"
 )
 
 var (
-	daemonPath   string
-	flags        string
-	mode         string
-	home         string
-	poolId       int64
-	seeds        string
-	targetHeight int64
-	chainId      string
-	restEndpoint string
-
-	quitCh = make(chan int)
+	daemonPath    string
+	flags         string
+	mode          string
+	home          string
+	poolIds       []string
+	seeds         string
+	targetHeights []string
+	chainId       string
+	restEndpoint  string
+	chunkWorkers  int64
+	execEndpoint  string
+	execWindow    int64
 )
 
 func init() {
-	startCmd.Flags().StringVar(&mode, "mode", utils.DefaultMode, fmt.Sprintf("sync mode (\"auto\",\"db\",\"p2p\"), [default = %s]", utils.DefaultMode))
+	startCmd.Flags().StringVar(&mode, "mode", utils.DefaultMode, fmt.Sprintf("sync mode (\"auto\",\"db\",\"p2p\",\"exec-grpc\"), [default = %s]", utils.DefaultMode))
 
 	startCmd.Flags().StringVar(&home, "home", "", "home directory")
 	if err := startCmd.MarkFlagRequired("home"); err != nil {
-		panic(fmt.Errorf("flag 'home' should be required: %w", err))
+		log.Fatalf("flag 'home' should be required: %v", err)
 	}
 
 	// Optional AUTO-MODE flags.
@@ -37,59 +38,94 @@ func init() {
 
 	startCmd.Flags().StringVar(&chainId, "chain-id", utils.DefaultChainId, fmt.Sprintf("kyve chain id (\"kyve-1\",\"kaon-1\",\"korellia\"), [default = %s]", utils.DefaultChainId))
 
-	startCmd.Flags().Int64Var(&poolId, "pool-id", 0, "pool id")
+	startCmd.Flags().StringArrayVar(&poolIds, "pool-id", nil, "pool id, can be given multiple times or as a comma-separated list to sync several pools")
 	if err := startCmd.MarkFlagRequired("pool-id"); err != nil {
-		panic(fmt.Errorf("flag 'pool-id' should be required: %w", err))
+		log.Fatalf("flag 'pool-id' should be required: %v", err)
 	}
 
 	startCmd.Flags().StringVar(&restEndpoint, "rest-endpoint", "", "Overwrite default rest endpoint from chain")
 
-	startCmd.Flags().Int64Var(&targetHeight, "target-height", 0, "target height (including)")
+	startCmd.Flags().StringArrayVar(&targetHeights, "target-height", nil, "target height (including), can be given once for all pools or once per --pool-id")
 
 	startCmd.Flags().StringVar(&seeds, "seeds", "", "P2P seeds to continue syncing process after KSYNC")
 
 	startCmd.Flags().StringVar(&flags, "flags", "", "Flags for starting the node to be synced; excluding --home and --with-tendermint")
 
+	startCmd.Flags().Int64Var(&chunkWorkers, "chunk-workers", 4, "number of concurrent workers for the snapshot chunk prefetcher; the value is stored but not read by the sync modes")
+
+	// Optional EXEC-GRPC-MODE flags.
+	startCmd.Flags().StringVar(&execEndpoint, "exec-endpoint", "", "gRPC endpoint of the external execution driver for mode \"exec-grpc\"")
+	startCmd.Flags().Int64Var(&execWindow, "exec-window", 0, "number of blocks the exec-grpc executor may pipeline ahead of confirmed commits, [default = 16]")
+
 	rootCmd.AddCommand(startCmd)
 }
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start fast syncing blocks with KSYNC",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// if no custom rest endpoint was given we take it from the chainId
-		if restEndpoint == "" {
-			switch chainId {
-			case "kyve-1":
-				restEndpoint = utils.RestEndpointMainnet
-			case "kaon-1":
-				restEndpoint = utils.RestEndpointKaon
-			case "korellia":
-				restEndpoint = utils.RestEndpointKorellia
-			default:
-				panic("flag --chain-id has to be either \"kyve-1\", \"kaon-1\" or \"korellia\"")
-			}
+		resolvedRest, err := utils.ResolveChainRest(chainId, restEndpoint)
+		if err != nil {
+			return err
 		}
+		restEndpoint = resolvedRest
+
+		// stored in flags.ChunkWorkers; no sync code path reads that value, so
+		// --chunk-workers does not change how snapshot chunks are downloaded
+		ksyncflags.ChunkWorkers = chunkWorkers
 
-		// trim trailing slash
-		restEndpoint = strings.TrimSuffix(restEndpoint, "/")
-
-		// start block executor based on sync mode
-		switch mode {
-		case "auto":
-			if daemonPath == "" {
-				panic("flag --daemon-path is required for mode \"auto\"")
-			}
-			auto.StartAutoExecutor(quitCh, home, daemonPath, seeds, flags, poolId, restEndpoint, targetHeight)
-		case "db":
-			go db.StartDBExecutor(quitCh, home, poolId, restEndpoint, targetHeight)
-		case "p2p":
-			go p2p.StartP2PExecutor(quitCh, home, poolId, restEndpoint, targetHeight)
-		default:
-			panic("flag --mode has to be either \"auto\", \"db\" or \"p2p\"")
+		tasks, err := buildStartTasks()
+		if err != nil {
+			return err
 		}
 
-		// only exit process if executor has finished
-		<-quitCh
+		// RunStart blocks until every pool's executor has finished
+		return orchestrator.RunStart(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint, execWindow, tasks, 0)
 	},
 }
+
+// buildStartTasks resolves the --pool-id and --target-height flags into one StartTask
+// per pool. --target-height may either be given once, in which case it applies to
+// every pool, or once per --pool-id, pairing them up in order. When syncing more than
+// one pool, each task gets its own sub-directory of --home so that concurrently-running
+// executors don't write into the same CometBFT home/blockstore; with a single pool,
+// --home is used as-is for backward compatibility.
+func buildStartTasks() ([]orchestrator.StartTask, error) {
+	pools, err := utils.ParseInt64List(poolIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --pool-id: %w", err)
+	}
+	if len(pools) == 0 {
+		pools = []int64{0}
+	}
+
+	heights, err := utils.ParseInt64List(targetHeights)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --target-height: %w", err)
+	}
+	if len(heights) == 0 {
+		heights = []int64{0}
+	}
+
+	if len(heights) != 1 && len(heights) != len(pools) {
+		return nil, fmt.Errorf("--target-height must be given once or once per --pool-id (%d pools), got %d values", len(pools), len(heights))
+	}
+
+	tasks := make([]orchestrator.StartTask, len(pools))
+	for i, poolId := range pools {
+		targetHeight := heights[0]
+		if len(heights) > 1 {
+			targetHeight = heights[i]
+		}
+
+		taskHome := home
+		if len(pools) > 1 {
+			taskHome = filepath.Join(home, fmt.Sprintf("pool-%d", poolId))
+		}
+
+		tasks[i] = orchestrator.StartTask{PoolId: poolId, TargetHeight: targetHeight, Home: taskHome}
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KYVENetwork/ksync/orchestrator"
+)
+
+func TestStartCmd_UnknownMode(t *testing.T) {
+	defer resetStartFlags()
+
+	chainId = "kyve-1"
+	restEndpoint = "https://api.example.com"
+	mode = "not-a-mode"
+
+	if err := startCmd.RunE(startCmd, nil); err == nil {
+		t.Fatal("expected an error for an unknown --mode, got none")
+	}
+}
+
+func TestStartCmd_AutoModeRequiresDaemonPath(t *testing.T) {
+	defer resetStartFlags()
+
+	chainId = "kyve-1"
+	restEndpoint = "https://api.example.com"
+	mode = "auto"
+	daemonPath = ""
+
+	if err := startCmd.RunE(startCmd, nil); err == nil {
+		t.Fatal("expected an error for mode \"auto\" without --daemon-path, got none")
+	}
+}
+
+func TestStartCmd_UnknownChainId(t *testing.T) {
+	defer resetStartFlags()
+
+	chainId = "not-a-chain"
+	restEndpoint = ""
+	mode = "db"
+
+	if err := startCmd.RunE(startCmd, nil); err == nil {
+		t.Fatal("expected an error for an unknown --chain-id, got none")
+	}
+}
+
+func TestBuildStartTasks_SharedTargetHeight(t *testing.T) {
+	defer resetStartFlags()
+
+	home = "/tmp/ksync-home"
+	poolIds = []string{"1,2", "3"}
+	targetHeights = []string{"100"}
+
+	tasks, err := buildStartTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []orchestrator.StartTask{
+		{PoolId: 1, TargetHeight: 100, Home: filepath.Join(home, "pool-1")},
+		{PoolId: 2, TargetHeight: 100, Home: filepath.Join(home, "pool-2")},
+		{PoolId: 3, TargetHeight: 100, Home: filepath.Join(home, "pool-3")},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(tasks))
+	}
+	for i := range want {
+		if tasks[i] != want[i] {
+			t.Fatalf("task %d: expected %+v, got %+v", i, want[i], tasks[i])
+		}
+	}
+}
+
+func TestBuildStartTasks_PairedTargetHeights(t *testing.T) {
+	defer resetStartFlags()
+
+	home = "/tmp/ksync-home"
+	poolIds = []string{"1", "2"}
+	targetHeights = []string{"100", "200"}
+
+	tasks, err := buildStartTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []orchestrator.StartTask{
+		{PoolId: 1, TargetHeight: 100, Home: filepath.Join(home, "pool-1")},
+		{PoolId: 2, TargetHeight: 200, Home: filepath.Join(home, "pool-2")},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(tasks))
+	}
+	for i := range want {
+		if tasks[i] != want[i] {
+			t.Fatalf("task %d: expected %+v, got %+v", i, want[i], tasks[i])
+		}
+	}
+}
+
+func TestBuildStartTasks_SinglePoolKeepsHome(t *testing.T) {
+	defer resetStartFlags()
+
+	home = "/tmp/ksync-home"
+	poolIds = []string{"1"}
+	targetHeights = []string{"100"}
+
+	tasks, err := buildStartTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []orchestrator.StartTask{{PoolId: 1, TargetHeight: 100, Home: home}}
+	if len(tasks) != len(want) {
+		t.Fatalf("expected %d tasks, got %d", len(want), len(tasks))
+	}
+	for i := range want {
+		if tasks[i] != want[i] {
+			t.Fatalf("task %d: expected %+v, got %+v", i, want[i], tasks[i])
+		}
+	}
+}
+
+func TestBuildStartTasks_MisalignedTargetHeights(t *testing.T) {
+	defer resetStartFlags()
+
+	poolIds = []string{"1", "2", "3"}
+	targetHeights = []string{"100", "200"}
+
+	if _, err := buildStartTasks(); err == nil {
+		t.Fatal("expected an error for misaligned --pool-id/--target-height counts, got none")
+	}
+}
+
+// resetStartFlags restores the package-level flag variables used by startCmd between
+// test cases since they are shared cobra flag targets
+func resetStartFlags() {
+	daemonPath = ""
+	flags = ""
+	mode = ""
+	home = ""
+	poolIds = nil
+	seeds = ""
+	targetHeights = nil
+	chainId = ""
+	restEndpoint = ""
+	chunkWorkers = 0
+	execEndpoint = ""
+	execWindow = 0
+}
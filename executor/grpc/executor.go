@@ -0,0 +1,122 @@
+package grpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/executor.proto
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KYVENetwork/ksync/app/collector"
+	"github.com/KYVENetwork/ksync/executor/grpc/proto"
+	"github.com/KYVENetwork/ksync/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// defaultInFlightWindow bounds how many blocks can be in flight to the external
+// execution driver before KSYNC applies back-pressure and waits for CommitBlock
+// confirmations to catch up
+const defaultInFlightWindow = int64(16)
+
+// StartGRPCExecutor pulls blocks for poolId from the KYVE block-sync pool exactly as
+// the other sync modes do, but instead of writing them to CometBFT's blockstore/state
+// DB it streams them to an external process over gRPC. It dials execEndpoint, calls
+// GetHeadBlock to find out where the external state machine left off, then pipelines
+// ExecuteBlock/CommitBlock calls with at most inFlightWindow blocks outstanding at
+// once. It returns once targetHeight has been committed (or indefinitely if
+// targetHeight is 0), or as soon as the first unrecoverable error is hit.
+func StartGRPCExecutor(home string, poolId int64, chainRest string, targetHeight int64, execEndpoint string, inFlightWindow int64) error {
+	if inFlightWindow <= 0 {
+		inFlightWindow = defaultInFlightWindow
+	}
+
+	return startGRPCExecutor(poolId, chainRest, targetHeight, execEndpoint, inFlightWindow)
+}
+
+func startGRPCExecutor(poolId int64, chainRest string, targetHeight int64, execEndpoint string, inFlightWindow int64) error {
+	conn, err := grpc.NewClient(execEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial exec-grpc endpoint %s: %w", execEndpoint, err)
+	}
+	defer conn.Close()
+
+	client := proto.NewExecutionDriverClient(conn)
+
+	blockCollector, err := collector.NewKyveBlockCollector(poolId, chainRest)
+	if err != nil {
+		return fmt.Errorf("failed to create block collector for pool %d: %w", poolId, err)
+	}
+
+	head, err := client.GetHeadBlock(context.Background(), &proto.GetHeadBlockRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get head block from exec-grpc endpoint: %w", err)
+	}
+
+	height := head.Height + 1
+
+	// inFlight is a bounded semaphore: each dispatched block holds a slot until its
+	// CommitBlock call returns, which is how back-pressure against the external
+	// execution driver is applied while blocks are still pipelined ahead of it
+	inFlight := make(chan struct{}, inFlightWindow)
+	errCh := make(chan error, 1)
+
+	for targetHeight == 0 || height <= targetHeight {
+		select {
+		case err := <-errCh:
+			return err
+		default:
+		}
+
+		block, err := blockCollector.GetBlock(height)
+		if err != nil {
+			return fmt.Errorf("failed to get block at height %d: %w", height, err)
+		}
+
+		inFlight <- struct{}{}
+
+		go func(height int64) {
+			defer func() { <-inFlight }()
+
+			if _, err := client.ExecuteBlock(context.Background(), &proto.ExecuteBlockRequest{
+				Height: block.Height,
+				Txs:    block.Txs,
+				Header: block.Header,
+			}); err != nil {
+				select {
+				case errCh <- fmt.Errorf("failed to execute block at height %d: %w", height, err):
+				default:
+				}
+				return
+			}
+
+			if _, err := client.CommitBlock(context.Background(), &proto.CommitBlockRequest{
+				Height: block.Height,
+			}); err != nil {
+				select {
+				case errCh <- fmt.Errorf("failed to commit block at height %d: %w", height, err):
+				default:
+				}
+			}
+		}(height)
+
+		height++
+		time.Sleep(utils.RequestTimeoutMS)
+	}
+
+	// drain remaining in-flight blocks before reporting success
+	for i := int64(0); i < inFlightWindow; i++ {
+		select {
+		case inFlight <- struct{}{}:
+		case err := <-errCh:
+			return err
+		}
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
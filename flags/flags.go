@@ -17,6 +17,7 @@ var (
 	RpcServerPort           int64
 	SnapshotPort            int64
 	BlockRpcReqTimeout      int64
+	ChunkWorkers            int64
 	Pruning                 bool
 	KeepSnapshots           bool
 	SkipWaiting             bool
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	heightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ksync_height",
+		Help: "current synced height, keyed by pool id",
+	}, []string{"pool_id"})
+
+	prefetcherBytesPerSecGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ksync_chunk_prefetcher_bytes_per_sec",
+		Help: "chunk prefetcher throughput in bytes per second, keyed by pool id",
+	}, []string{"pool_id"})
+
+	prefetcherInFlightBundlesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ksync_chunk_prefetcher_in_flight_bundles",
+		Help: "number of snapshot chunk bundles currently being fetched, keyed by pool id",
+	}, []string{"pool_id"})
+
+	prefetcherCacheHitsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ksync_chunk_prefetcher_cache_hits",
+		Help: "number of chunk prefetcher lookups served from cache, keyed by pool id",
+	}, []string{"pool_id"})
+)
+
+func init() {
+	registry.MustRegister(heightGauge, prefetcherBytesPerSecGauge, prefetcherInFlightBundlesGauge, prefetcherCacheHitsGauge)
+}
+
+// SetHeight records the current synced height for poolId
+func SetHeight(poolId, height int64) {
+	heightGauge.WithLabelValues(strconv.FormatInt(poolId, 10)).Set(float64(height))
+}
+
+// SetChunkPrefetcherMetrics records a ChunkPrefetcher's progress for poolId. It takes
+// plain scalar values rather than collector.ChunkPrefetcherMetrics directly, since the
+// collector package imports metrics to report them and a metrics -> collector import
+// would create a cycle.
+func SetChunkPrefetcherMetrics(poolId int64, bytesPerSec float64, inFlightBundles, cacheHits int64) {
+	label := strconv.FormatInt(poolId, 10)
+	prefetcherBytesPerSecGauge.WithLabelValues(label).Set(bytesPerSec)
+	prefetcherInFlightBundlesGauge.WithLabelValues(label).Set(float64(inFlightBundles))
+	prefetcherCacheHitsGauge.WithLabelValues(label).Set(float64(cacheHits))
+}
+
+// StartServer serves the registered metrics on /metrics on the given port. It blocks
+// until the server stops, so callers should run it in its own goroutine.
+func StartServer(port int64) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+		return fmt.Errorf("failed to start metrics server on port %d: %w", port, err)
+	}
+
+	return nil
+}
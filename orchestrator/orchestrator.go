@@ -0,0 +1,153 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/KYVENetwork/ksync/executor/auto"
+	"github.com/KYVENetwork/ksync/executor/db"
+	grpcExecutor "github.com/KYVENetwork/ksync/executor/grpc"
+	"github.com/KYVENetwork/ksync/executor/p2p"
+	"github.com/KYVENetwork/ksync/servesnapshots"
+	"github.com/KYVENetwork/ksync/utils"
+)
+
+// defaultRequestsPerSecond bounds how often RunStart and RunServe may start another
+// task when the caller does not request a specific rate
+const defaultRequestsPerSecond = 10
+
+// StartTask describes a single (pool, height) pair to sync with a block executor, each
+// into its own Home directory so that concurrently-running tasks don't write into the
+// same CometBFT home/blockstore
+type StartTask struct {
+	PoolId       int64
+	TargetHeight int64
+	Home         string
+}
+
+// execTask is indirected through a package-level variable so that RunStart's
+// per-task dispatch can be exercised in unit tests without spinning up real executors
+var execTask = defaultExecTask
+
+// defaultExecTask dispatches a single StartTask to the executor for mode, using
+// task.Home rather than a home directory shared across tasks, and returns its error
+// instead of panicking or signaling only through quitCh
+func defaultExecTask(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint string, execWindow int64, task StartTask) error {
+	quitCh := make(chan int)
+
+	switch mode {
+	case "auto":
+		if daemonPath == "" {
+			return fmt.Errorf("flag --daemon-path is required for mode \"auto\"")
+		}
+		auto.StartAutoExecutor(quitCh, task.Home, daemonPath, seeds, flags, task.PoolId, restEndpoint, task.TargetHeight)
+	case "db":
+		go db.StartDBExecutor(quitCh, task.Home, task.PoolId, restEndpoint, task.TargetHeight)
+	case "p2p":
+		go p2p.StartP2PExecutor(quitCh, task.Home, task.PoolId, restEndpoint, task.TargetHeight)
+	case "exec-grpc":
+		if execEndpoint == "" {
+			return fmt.Errorf("flag --exec-endpoint is required for mode \"exec-grpc\"")
+		}
+		// StartGRPCExecutor runs synchronously and returns its error instead of
+		// signaling over quitCh
+		return grpcExecutor.StartGRPCExecutor(task.Home, task.PoolId, restEndpoint, task.TargetHeight, execEndpoint, execWindow)
+	default:
+		return fmt.Errorf("flag --mode has to be either \"auto\", \"db\", \"p2p\" or \"exec-grpc\"")
+	}
+
+	<-quitCh
+	return nil
+}
+
+// RunStart spins up one block executor per task, each into its own task.Home, so that
+// several pools or height windows can be back-filled from one KSYNC invocation. A single
+// shared rate limiter spaces out the start of each task; the REST requests the executors
+// make once running are not throttled by it. It blocks until every executor has
+// finished, returning the first error encountered, if any.
+func RunStart(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint string, execWindow int64, tasks []StartTask, requestsPerSecond int) error {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = defaultRequestsPerSecond
+	}
+
+	limiter := utils.NewRateLimiter(requestsPerSecond)
+	defer limiter.Stop()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(tasks))
+
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task StartTask) {
+			defer wg.Done()
+
+			limiter.Wait()
+
+			if err := execTask(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint, execWindow, task); err != nil {
+				errCh <- err
+			}
+		}(task)
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServeTask describes a single (block-pool, snapshot-pool, start-height) tuple to serve
+// snapshots for, each with its own HomePath, SnapshotPort and MetricsPort so that
+// concurrently-running tasks don't bind the same ports or write into the same home
+// directory
+type ServeTask struct {
+	BlockPoolId    int64
+	SnapshotPoolId int64
+	StartHeight    int64
+	HomePath       string
+	SnapshotPort   int64
+	MetricsPort    int64
+}
+
+// execServeTask is indirected through a package-level variable so that RunServe's
+// per-task dispatch can be exercised in unit tests without spinning up real executors
+var execServeTask = defaultExecServeTask
+
+func defaultExecServeTask(binaryPath, chainRest, storageRest string, metrics bool, pruning bool, task ServeTask) {
+	servesnapshots.StartServeSnapshotsWithBinary(binaryPath, task.HomePath, chainRest, storageRest, task.BlockPoolId, metrics, task.MetricsPort, task.SnapshotPoolId, task.SnapshotPort, task.StartHeight, pruning)
+}
+
+// RunServe spins up one serve-snapshots executor per task, each into its own
+// task.HomePath and binding its own task.SnapshotPort/task.MetricsPort, so operators can
+// serve snapshots for several networks from one KSYNC invocation. A single shared rate
+// limiter spaces out the start of each task; the REST requests the executors make once
+// running are not throttled by it. It blocks until every executor has finished.
+func RunServe(binaryPath, chainRest, storageRest string, metrics bool, pruning bool, tasks []ServeTask, requestsPerSecond int) error {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = defaultRequestsPerSecond
+	}
+
+	limiter := utils.NewRateLimiter(requestsPerSecond)
+	defer limiter.Stop()
+
+	var wg sync.WaitGroup
+
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task ServeTask) {
+			defer wg.Done()
+
+			limiter.Wait()
+
+			execServeTask(binaryPath, chainRest, storageRest, metrics, pruning, task)
+		}(task)
+	}
+
+	wg.Wait()
+	return nil
+}
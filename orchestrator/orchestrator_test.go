@@ -0,0 +1,138 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRunStart_ConcurrentTasksUseDistinctHomes runs several tasks through RunStart at
+// once and asserts that they actually overlap in time and that none of them see the
+// same Home as another, i.e. that tasks are isolated rather than serialized or sharing
+// state
+func TestRunStart_ConcurrentTasksUseDistinctHomes(t *testing.T) {
+	original := execTask
+	t.Cleanup(func() { execTask = original })
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	seenHomes := make(map[string]bool)
+
+	execTask = func(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint string, execWindow int64, task StartTask) error {
+		mu.Lock()
+		if seenHomes[task.Home] {
+			t.Errorf("home %q reused across tasks", task.Home)
+		}
+		seenHomes[task.Home] = true
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+
+		return nil
+	}
+
+	tasks := []StartTask{
+		{PoolId: 1, TargetHeight: 0, Home: "/tmp/ksync-test/pool-1"},
+		{PoolId: 2, TargetHeight: 0, Home: "/tmp/ksync-test/pool-2"},
+		{PoolId: 3, TargetHeight: 0, Home: "/tmp/ksync-test/pool-3"},
+	}
+
+	if err := RunStart("db", "", "", "", "http://localhost", "", 0, tasks, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxActive < 2 {
+		t.Fatalf("expected at least 2 tasks to run concurrently, max concurrent was %d", maxActive)
+	}
+	if len(seenHomes) != len(tasks) {
+		t.Fatalf("expected %d distinct homes, got %d", len(tasks), len(seenHomes))
+	}
+}
+
+// TestRunStart_PropagatesTaskError asserts that an error returned by a single task's
+// executor is still surfaced even though every task runs concurrently
+func TestRunStart_PropagatesTaskError(t *testing.T) {
+	original := execTask
+	t.Cleanup(func() { execTask = original })
+
+	wantErr := fmt.Errorf("boom")
+	execTask = func(mode, daemonPath, seeds, flags, restEndpoint, execEndpoint string, execWindow int64, task StartTask) error {
+		if task.PoolId == 2 {
+			return wantErr
+		}
+		return nil
+	}
+
+	tasks := []StartTask{
+		{PoolId: 1, Home: "/tmp/ksync-test/pool-1"},
+		{PoolId: 2, Home: "/tmp/ksync-test/pool-2"},
+	}
+
+	if err := RunStart("db", "", "", "", "http://localhost", "", 0, tasks, 1000); err == nil {
+		t.Fatal("expected an error to be propagated, got none")
+	}
+}
+
+// TestRunServe_ConcurrentTasksUseDistinctPortsAndHomes mirrors
+// TestRunStart_ConcurrentTasksUseDistinctHomes for RunServe, asserting tasks don't
+// collide on HomePath, SnapshotPort or MetricsPort
+func TestRunServe_ConcurrentTasksUseDistinctPortsAndHomes(t *testing.T) {
+	original := execServeTask
+	t.Cleanup(func() { execServeTask = original })
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	seenHomes := make(map[string]bool)
+	seenSnapshotPorts := make(map[int64]bool)
+	seenMetricsPorts := make(map[int64]bool)
+
+	execServeTask = func(binaryPath, chainRest, storageRest string, metrics bool, pruning bool, task ServeTask) {
+		mu.Lock()
+		if seenHomes[task.HomePath] {
+			t.Errorf("home %q reused across tasks", task.HomePath)
+		}
+		if seenSnapshotPorts[task.SnapshotPort] {
+			t.Errorf("snapshot port %d reused across tasks", task.SnapshotPort)
+		}
+		if seenMetricsPorts[task.MetricsPort] {
+			t.Errorf("metrics port %d reused across tasks", task.MetricsPort)
+		}
+		seenHomes[task.HomePath] = true
+		seenSnapshotPorts[task.SnapshotPort] = true
+		seenMetricsPorts[task.MetricsPort] = true
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	tasks := []ServeTask{
+		{BlockPoolId: 1, SnapshotPoolId: 11, HomePath: "/tmp/ksync-test/serve-1", SnapshotPort: 10201, MetricsPort: 10301},
+		{BlockPoolId: 2, SnapshotPoolId: 12, HomePath: "/tmp/ksync-test/serve-2", SnapshotPort: 10202, MetricsPort: 10302},
+		{BlockPoolId: 3, SnapshotPoolId: 13, HomePath: "/tmp/ksync-test/serve-3", SnapshotPort: 10203, MetricsPort: 10303},
+	}
+
+	if err := RunServe("", "http://localhost", "http://localhost", true, true, tasks, 1000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxActive < 2 {
+		t.Fatalf("expected at least 2 tasks to run concurrently, max concurrent was %d", maxActive)
+	}
+}
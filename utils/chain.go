@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveChainRest resolves the REST endpoint to use for the given KYVE chain id. If
+// override is non-empty it is used as-is (trailing slash trimmed), otherwise chainId
+// is mapped to the corresponding well-known endpoint. It returns an error instead of
+// panicking when chainId is not one of the known chains, so callers can surface it as
+// a regular CLI error.
+func ResolveChainRest(chainId, override string) (string, error) {
+	if override != "" {
+		return strings.TrimSuffix(override, "/"), nil
+	}
+
+	switch chainId {
+	case ChainIdMainnet:
+		return RestEndpointMainnet, nil
+	case ChainIdKaon:
+		return RestEndpointKaon, nil
+	case ChainIdKorellia:
+		return RestEndpointKorellia, nil
+	default:
+		return "", fmt.Errorf("flag --chain-id has to be either %q, %q or %q", ChainIdMainnet, ChainIdKaon, ChainIdKorellia)
+	}
+}
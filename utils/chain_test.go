@@ -0,0 +1,37 @@
+package utils
+
+import "testing"
+
+func TestResolveChainRest(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainId  string
+		override string
+		want     string
+		wantErr  bool
+	}{
+		{name: "mainnet", chainId: ChainIdMainnet, want: RestEndpointMainnet},
+		{name: "kaon", chainId: ChainIdKaon, want: RestEndpointKaon},
+		{name: "korellia", chainId: ChainIdKorellia, want: RestEndpointKorellia},
+		{name: "override takes precedence", chainId: ChainIdMainnet, override: "https://custom.example.com/", want: "https://custom.example.com"},
+		{name: "unknown chain id", chainId: "not-a-chain", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveChainRest(tt.chainId, tt.override)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for chain id %q, got none", tt.chainId)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected rest endpoint %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
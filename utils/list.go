@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var csvSplitPattern = regexp.MustCompile(`\s*,\s*`)
+
+// ParseInt64List joins a slice of raw flag values (each of which may itself already be
+// a comma-delimited list, as produced by a repeatable cobra flag) into a single
+// comma-delimited string and splits it on csvSplitPattern, the same way repeated query
+// parameters are combined before being split. Empty entries are ignored so both
+// "--flag a --flag b" and "--flag a,b" yield the same result.
+func ParseInt64List(raw []string) ([]int64, error) {
+	joined := strings.Join(raw, ",")
+
+	var values []int64
+	for _, entry := range csvSplitPattern.Split(joined, -1) {
+		if entry == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(entry, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q as int64: %w", entry, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInt64List(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []string
+		want    []int64
+		wantErr bool
+	}{
+		{name: "single value", raw: []string{"1"}, want: []int64{1}},
+		{name: "comma-delimited value", raw: []string{"1,2,3"}, want: []int64{1, 2, 3}},
+		{name: "repeated flags", raw: []string{"1", "2", "3"}, want: []int64{1, 2, 3}},
+		{name: "mixed repeated and comma-delimited", raw: []string{"1, 2", "3"}, want: []int64{1, 2, 3}},
+		{name: "empty", raw: nil, want: nil},
+		{name: "invalid entry", raw: []string{"1,not-a-number"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInt64List(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %v, got none", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
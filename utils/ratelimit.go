@@ -0,0 +1,33 @@
+package utils
+
+import "time"
+
+// RateLimiter throttles callers to at most one acquisition per interval. The
+// orchestrator shares one RateLimiter across its tasks and calls Wait once per task
+// before starting it, which spaces out the task starts; the REST requests an executor
+// makes once it is running do not go through the limiter.
+type RateLimiter struct {
+	ticker *time.Ticker
+}
+
+// NewRateLimiter returns a RateLimiter that permits at most requestsPerSecond calls to
+// Wait per second.
+func NewRateLimiter(requestsPerSecond int) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = 1
+	}
+
+	return &RateLimiter{
+		ticker: time.NewTicker(time.Second / time.Duration(requestsPerSecond)),
+	}
+}
+
+// Wait blocks until the caller is allowed to make its next request.
+func (r *RateLimiter) Wait() {
+	<-r.ticker.C
+}
+
+// Stop releases the resources held by the RateLimiter.
+func (r *RateLimiter) Stop() {
+	r.ticker.Stop()
+}